@@ -0,0 +1,209 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// errBadChannel is the value Feed.Subscribe panics with when the supplied
+// argument is not a sendable channel.
+var errBadChannel = errors.New("marathon: Subscribe argument does not have sendable channel type")
+
+// errSlowSubscriber is delivered on a Subscription's Err channel when its
+// channel isn't being drained fast enough to keep up with Feed.Send.
+var errSlowSubscriber = errors.New("marathon: subscriber is slow, dropped")
+
+// Subscription represents a subscription to events delivered by a Feed. The
+// event type delivered is determined by the channel passed to Subscribe.
+//
+// Subscriptions can fail while they are running, in which case the failure
+// is sent on the channel returned by Err. Subscriptions are canceled by
+// calling Unsubscribe, which is also the only way to remove a subscriber
+// from a Feed's listener set.
+type Subscription interface {
+	// Unsubscribe cancels delivery to the channel and removes the
+	// subscription from the feed. It is safe to call more than once.
+	Unsubscribe()
+	// Err returns a channel that receives an error if the subscription is
+	// terminated abnormally (e.g. by a slow consumer), and is closed when
+	// the subscription ends.
+	Err() <-chan error
+}
+
+// Feed implements one-to-many delivery of values of a single static type to
+// subscribed channels. A zero Feed is ready to use. Feeds are safe for
+// concurrent use by multiple goroutines.
+//
+// Unlike a plain channel fan-out, a subscriber that falls behind does not
+// block Send: it receives errSlowSubscriber on its Err channel and is
+// dropped from the feed instead.
+type Feed struct {
+	mu   sync.Mutex
+	subs map[*feedSub]struct{}
+}
+
+type feedSub struct {
+	feed *Feed
+	ch   reflect.Value
+	errC chan error
+	once sync.Once
+}
+
+// Subscribe adds a subscription to the feed, delivering subsequently sent
+// values that are assignable to channel's element type onto channel.
+// channel must be a writable, non-nil channel.
+func (f *Feed) Subscribe(channel interface{}) Subscription {
+	chanVal := reflect.ValueOf(channel)
+	chanType := chanVal.Type()
+	if chanType.Kind() != reflect.Chan || chanType.ChanDir()&reflect.SendDir == 0 {
+		panic(errBadChannel)
+	}
+
+	sub := &feedSub{feed: f, ch: chanVal, errC: make(chan error, 1)}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.subs == nil {
+		f.subs = make(map[*feedSub]struct{})
+	}
+	f.subs[sub] = struct{}{}
+	return sub
+}
+
+// Send delivers value to every subscriber whose channel can carry it, and
+// returns the number of subscribers it was delivered to. A subscriber whose
+// channel is full is dropped rather than blocking the sender.
+func (f *Feed) Send(value interface{}) int {
+	rvalue := reflect.ValueOf(value)
+
+	f.mu.Lock()
+	recipients := make([]*feedSub, 0, len(f.subs))
+	for sub := range f.subs {
+		if rvalue.Type().AssignableTo(sub.ch.Type().Elem()) {
+			recipients = append(recipients, sub)
+		}
+	}
+	f.mu.Unlock()
+
+	delivered := 0
+	for _, sub := range recipients {
+		chosen, _, _ := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectSend, Chan: sub.ch, Send: rvalue},
+			{Dir: reflect.SelectDefault},
+		})
+		if chosen == 0 {
+			delivered++
+			continue
+		}
+
+		select {
+		case sub.errC <- errSlowSubscriber:
+		default:
+		}
+		sub.Unsubscribe()
+	}
+	return delivered
+}
+
+func (sub *feedSub) Unsubscribe() {
+	sub.once.Do(func() {
+		sub.feed.mu.Lock()
+		delete(sub.feed.subs, sub)
+		sub.feed.mu.Unlock()
+		close(sub.errC)
+	})
+}
+
+func (sub *feedSub) Err() <-chan error {
+	return sub.errC
+}
+
+// feedRegistry keeps one Feed per concrete event type, created lazily as
+// subscribers appear. The zero value is ready to use.
+type feedRegistry struct {
+	mu    sync.Mutex
+	feeds map[reflect.Type]*Feed
+}
+
+// feedFor returns the Feed dedicated to sample's concrete type, creating it
+// on first use. sample is only used for its type; pass a typed nil pointer,
+// e.g. (*EventDeploymentSuccess)(nil).
+func (r *feedRegistry) feedFor(sample interface{}) *Feed {
+	t := reflect.TypeOf(sample)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.feeds == nil {
+		r.feeds = make(map[reflect.Type]*Feed)
+	}
+	feed, found := r.feeds[t]
+	if !found {
+		feed = new(Feed)
+		r.feeds[t] = feed
+	}
+	return feed
+}
+
+// send fans value out to the Feed matching its concrete type, if any
+// subscribers have ever asked for that type.
+func (r *feedRegistry) send(value interface{}) {
+	t := reflect.TypeOf(value)
+
+	r.mu.Lock()
+	feed, found := r.feeds[t]
+	r.mu.Unlock()
+
+	if found {
+		feed.Send(value)
+	}
+}
+
+// SubscribeDeploymentEvents registers the subscription to receive decoded
+// EventDeploymentSuccess events off the shared event stream on ch, until the
+// returned Subscription is unsubscribed.
+//
+// Holding r.Lock() across registerSubscription here is safe for
+// EventsTransportSSE because SSE's own bookkeeping (subscribedToSSE,
+// sseState, sseErrors, the last-event-ID store) is guarded by the separate
+// sseMu, not r's main lock; see the SubscriptionState doc comment in
+// subscription.go.
+func (r *marathonClient) SubscribeDeploymentEvents(ch chan<- *EventDeploymentSuccess) (Subscription, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if err := r.registerSubscription(); err != nil {
+		return nil, err
+	}
+	return r.feeds.feedFor((*EventDeploymentSuccess)(nil)).Subscribe(ch), nil
+}
+
+// SubscribeStatusUpdates registers the subscription to receive decoded
+// EventStatusUpdate events off the shared event stream on ch, until the
+// returned Subscription is unsubscribed. See SubscribeDeploymentEvents for
+// why holding r.Lock() across registerSubscription is safe here.
+func (r *marathonClient) SubscribeStatusUpdates(ch chan<- *EventStatusUpdate) (Subscription, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if err := r.registerSubscription(); err != nil {
+		return nil, err
+	}
+	return r.feeds.feedFor((*EventStatusUpdate)(nil)).Subscribe(ch), nil
+}
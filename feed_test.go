@@ -0,0 +1,94 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import "testing"
+
+func TestFeedSendDeliversToSubscriber(t *testing.T) {
+	var f Feed
+	ch := make(chan int, 1)
+	sub := f.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	if n := f.Send(42); n != 1 {
+		t.Fatalf("expected 1 delivery, got %d", n)
+	}
+	if got := <-ch; got != 42 {
+		t.Fatalf("expected 42, got %d", got)
+	}
+}
+
+func TestFeedSendDropsSlowSubscriber(t *testing.T) {
+	var f Feed
+	ch := make(chan int) // unbuffered, nobody reads it
+	sub := f.Subscribe(ch)
+
+	if n := f.Send(1); n != 0 {
+		t.Fatalf("expected 0 deliveries to a blocked subscriber, got %d", n)
+	}
+
+	select {
+	case err := <-sub.Err():
+		if err != errSlowSubscriber {
+			t.Fatalf("expected errSlowSubscriber, got %v", err)
+		}
+	default:
+		t.Fatal("expected an error on Err() for a dropped slow subscriber")
+	}
+}
+
+func TestFeedSendIgnoresMismatchedType(t *testing.T) {
+	var f Feed
+	ch := make(chan int, 1)
+	f.Subscribe(ch)
+
+	if n := f.Send("not an int"); n != 0 {
+		t.Fatalf("expected 0 deliveries for a mismatched type, got %d", n)
+	}
+}
+
+func TestFeedRegistryFeedForIsPerType(t *testing.T) {
+	var reg feedRegistry
+	a := reg.feedFor((*int)(nil))
+	b := reg.feedFor((*int)(nil))
+	c := reg.feedFor((*string)(nil))
+
+	if a != b {
+		t.Fatal("expected feedFor to return the same Feed for the same type")
+	}
+	if a == c {
+		t.Fatal("expected feedFor to return distinct Feeds for distinct types")
+	}
+}
+
+func TestFeedRegistrySendRoutesByType(t *testing.T) {
+	var reg feedRegistry
+	ch := make(chan *int, 1)
+	reg.feedFor((*int)(nil)).Subscribe(ch)
+
+	value := 7
+	reg.send(&value)
+
+	select {
+	case got := <-ch:
+		if *got != 7 {
+			t.Fatalf("expected 7, got %d", *got)
+		}
+	default:
+		t.Fatal("expected the value to be delivered to the matching feed")
+	}
+}
@@ -17,17 +17,86 @@ limitations under the License.
 package marathon
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/donovanhide/eventsource"
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// errSSEHeartbeatTimeout is delivered to SSEErrors when the heartbeat
+// watchdog forces a reconnect because no event arrived within
+// Config.SSEHeartbeatTimeout.
+var errSSEHeartbeatTimeout = errors.New("marathon: no SSE event received within heartbeat timeout")
+
+// SSELastEventIDStore persists the last SSE event ID observed, so that a
+// reconnect can ask Marathon to replay events missed in between. An
+// implementation backed by Redis or a file can make this survive process
+// restarts; the default in-memory store does not.
+type SSELastEventIDStore interface {
+	// Get returns the last persisted event ID, or "" if none is stored yet.
+	Get() (string, error)
+	// Set persists id as the last observed event ID.
+	Set(id string) error
+}
+
+// memoryLastEventIDStore is the SSELastEventIDStore used when
+// Config.SSELastEventIDStore is left unset.
+type memoryLastEventIDStore struct {
+	mu sync.Mutex
+	id string
+}
+
+func (s *memoryLastEventIDStore) Get() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id, nil
+}
+
+func (s *memoryLastEventIDStore) Set(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.id = id
+	return nil
+}
+
+// SubscriptionState describes the current state of the SSE event stream subscription.
+//
+// All SSE bookkeeping (sseState, sseErrors, subscribedToSSE and the
+// last-event-ID store) is guarded by marathonClient's sseMu, kept separate
+// from the client's main lock. registerSubscription is reachable from
+// AddEventsListenerCtx and the typed Subscribe* methods while they still
+// hold the main lock, so SSE internals must not try to re-acquire it.
+type SubscriptionState int
+
+const (
+	// SubscriptionDisconnected means no SSE stream is currently established.
+	SubscriptionDisconnected SubscriptionState = iota
+	// SubscriptionConnecting means a connection attempt to a cluster member is in progress.
+	SubscriptionConnecting
+	// SubscriptionConnected means events are currently being streamed from a cluster member.
+	SubscriptionConnected
+	// SubscriptionReconnecting means the stream was lost and a reconnect is being attempted.
+	SubscriptionReconnecting
+)
+
+const (
+	// defaultSSEReconnectBackoff is used when Config.SSEReconnectBackoff is unset.
+	defaultSSEReconnectBackoff = 1 * time.Second
+	// defaultSSEMaxBackoff is used when Config.SSEMaxBackoff is unset.
+	defaultSSEMaxBackoff = 30 * time.Second
+)
+
 // Subscriptions is a collection to urls that marathon is implementing a callback on
 type Subscriptions struct {
 	CallbackURLs []string `json:"callbackUrls"`
@@ -43,9 +112,93 @@ func (r *marathonClient) Subscriptions() (*Subscriptions, error) {
 	return subscriptions, nil
 }
 
+// SlowConsumerPolicy controls what the client does with an event when a
+// listener's channel isn't being drained fast enough to keep up.
+type SlowConsumerPolicy int
+
+const (
+	// Block waits for the listener to have room, exactly like the legacy
+	// AddEventsListener behaviour. It is the default policy.
+	Block SlowConsumerPolicy = iota
+	// DropOldest discards the oldest event currently buffered on the
+	// listener's channel to make room for the new one.
+	DropOldest
+	// DropNewest discards the incoming event, leaving whatever is already
+	// buffered on the listener's channel untouched.
+	DropNewest
+)
+
+// ListenerOptions configures a listener registered via AddEventsListenerCtx.
+type ListenerOptions struct {
+	// Filter is the legacy event-type bitmask; an event is only considered
+	// for delivery if event.ID&Filter is non-zero. Zero (the default) means
+	// no bitmask restriction, so a listener built with only EventTypes set
+	// still receives matching events.
+	Filter int
+	// EventTypes, if non-empty, additionally restricts delivery to events
+	// whose type name (e.g. "deployment_success") appears in the slice.
+	EventTypes []string
+	// SendTimeout bounds how long delivery waits for a blocked listener
+	// before SlowConsumerPolicy is applied. Zero means wait forever.
+	SendTimeout time.Duration
+	// SlowConsumerPolicy decides what happens once SendTimeout elapses.
+	SlowConsumerPolicy SlowConsumerPolicy
+}
+
+// ListenerMetrics is a snapshot of delivery statistics for a single listener.
+type ListenerMetrics struct {
+	// Delivered is the number of events successfully sent to the listener.
+	Delivered uint64
+	// Dropped is the number of events discarded, either under a drop policy
+	// or because the listener's context ended before the event was sent.
+	Dropped uint64
+	// BlockedMS is the cumulative time, in milliseconds, spent waiting on a
+	// blocked listener before SendTimeout elapsed.
+	BlockedMS int64
+}
+
+// listenerRegistration is the internal bookkeeping kept per registered
+// listener channel.
+type listenerRegistration struct {
+	ctx     context.Context
+	options ListenerOptions
+	cancel  context.CancelFunc
+	metrics ListenerMetrics
+}
+
+// wants reports whether event should be delivered to this listener.
+func (reg *listenerRegistration) wants(event *Event, eventType string) bool {
+	if reg.options.Filter != 0 && event.ID&reg.options.Filter == 0 {
+		return false
+	}
+	if len(reg.options.EventTypes) == 0 {
+		return true
+	}
+	for _, name := range reg.options.EventTypes {
+		if name == eventType {
+			return true
+		}
+	}
+	return false
+}
+
 // AddEventsListener adds your self as a listener to events from Marathon
 //		channel:	a EventsChannel used to receive event on
 func (r *marathonClient) AddEventsListener(channel EventsChannel, filter int) error {
+	return r.AddEventsListenerCtx(context.Background(), channel, ListenerOptions{
+		Filter:             filter,
+		SlowConsumerPolicy: Block,
+	})
+}
+
+// AddEventsListenerCtx registers channel to receive events matching opts.
+// Delivery honors opts.SendTimeout and opts.SlowConsumerPolicy
+// (AddEventsListener uses no timeout and Block), and the listener is
+// automatically removed once ctx is done.
+//		ctx:		controls the lifetime of the registration
+//		channel:	a EventsChannel used to receive events on
+//		opts:		filtering, timeout and slow-consumer behaviour
+func (r *marathonClient) AddEventsListenerCtx(ctx context.Context, channel EventsChannel, opts ListenerOptions) error {
 	r.Lock()
 	defer r.Unlock()
 
@@ -55,9 +208,18 @@ func (r *marathonClient) AddEventsListener(channel EventsChannel, filter int) er
 		return err
 	}
 
-	if _, found := r.listeners[channel]; !found {
-		r.listeners[channel] = filter
+	if _, found := r.listeners[channel]; found {
+		return nil
 	}
+
+	listenerCtx, cancel := context.WithCancel(ctx)
+	r.listeners[channel] = &listenerRegistration{ctx: listenerCtx, options: opts, cancel: cancel}
+
+	go func() {
+		<-listenerCtx.Done()
+		r.RemoveEventsListener(channel)
+	}()
+
 	return nil
 }
 
@@ -67,8 +229,9 @@ func (r *marathonClient) RemoveEventsListener(channel EventsChannel) {
 	r.Lock()
 	defer r.Unlock()
 
-	if _, found := r.listeners[channel]; found {
+	if reg, found := r.listeners[channel]; found {
 		delete(r.listeners, channel)
+		reg.cancel()
 		// step: if there is no one else listening, let's remove ourselves
 		// from the events callback
 		if r.config.EventsTransport == EventsTransportCallback && len(r.listeners) == 0 {
@@ -77,6 +240,23 @@ func (r *marathonClient) RemoveEventsListener(channel EventsChannel) {
 	}
 }
 
+// ListenerStats returns a snapshot of delivery metrics for channel, and false
+// if channel isn't currently registered.
+func (r *marathonClient) ListenerStats(channel EventsChannel) (ListenerMetrics, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	reg, found := r.listeners[channel]
+	if !found {
+		return ListenerMetrics{}, false
+	}
+	return ListenerMetrics{
+		Delivered: atomic.LoadUint64(&reg.metrics.Delivered),
+		Dropped:   atomic.LoadUint64(&reg.metrics.Dropped),
+		BlockedMS: atomic.LoadInt64(&reg.metrics.BlockedMS),
+	}, true
+}
+
 // SubscriptionURL retrieves the subscription callback URL used when registering
 func (r *marathonClient) SubscriptionURL() string {
 	if r.config.CallbackURL != "" {
@@ -100,35 +280,28 @@ func (r *marathonClient) registerSubscription() error {
 
 func (r *marathonClient) registerCallbackSubscription() error {
 	if r.eventsHTTP == nil {
-		ipAddress, err := getInterfaceAddress(r.config.EventsInterface)
+		listener, err := r.callbackListener()
 		if err != nil {
-			return fmt.Errorf("Unable to get the ip address from the interface: %s, error: %s",
-				r.config.EventsInterface, err)
+			return err
 		}
 
-		// step: set the ip address
-		r.ipAddress = ipAddress
-		binding := fmt.Sprintf("%s:%d", ipAddress, r.config.EventsPort)
-		// step: register the handler
-		http.HandleFunc(defaultEventsURL, r.handleCallbackEvent)
+		// step: register the handler on a private mux, rather than
+		// http.DefaultServeMux, so the client can be instantiated more than
+		// once in the same process
+		mux := http.NewServeMux()
+		mux.HandleFunc(defaultEventsURL, r.handleCallbackEvent)
+
 		// step: create the http server
 		r.eventsHTTP = &http.Server{
-			Addr:           binding,
-			Handler:        nil,
+			Handler:        mux,
 			ReadTimeout:    10 * time.Second,
 			WriteTimeout:   10 * time.Second,
 			MaxHeaderBytes: 1 << 20,
 		}
 
-		// @todo need to add a timeout value here
-		listener, err := net.Listen("tcp", binding)
-		if err != nil {
-			return nil
-		}
-
 		go func() {
-			for {
-				r.eventsHTTP.Serve(listener)
+			if err := r.eventsHTTP.Serve(listener); err != nil && err != http.ErrServerClosed {
+				log.Printf("event callback server stopped serving, error: %s", err)
 			}
 		}()
 	}
@@ -152,47 +325,328 @@ func (r *marathonClient) registerCallbackSubscription() error {
 	return nil
 }
 
+// callbackListener returns the net.Listener the event callback server should
+// serve on. Config.EventsListener, if set, is used as-is so callers can
+// supply their own listener (already wrapped in TLS, proxied, etc).
+// Otherwise a listener is bound to Config.EventsInterface/EventsPort,
+// wrapped in Config.EventsTLSConfig if one is set.
+func (r *marathonClient) callbackListener() (net.Listener, error) {
+	if r.config.EventsListener != nil {
+		if err := r.adoptListenerAddress(r.config.EventsListener); err != nil {
+			return nil, err
+		}
+		return r.config.EventsListener, nil
+	}
+
+	ipAddress, err := getInterfaceAddress(r.config.EventsInterface)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the ip address from the interface: %s, error: %s",
+			r.config.EventsInterface, err)
+	}
+	// step: set the ip address
+	r.ipAddress = ipAddress
+
+	binding := fmt.Sprintf("%s:%d", ipAddress, r.config.EventsPort)
+	if r.config.EventsTLSConfig != nil {
+		return tls.Listen("tcp", binding, r.config.EventsTLSConfig)
+	}
+	return net.Listen("tcp", binding)
+}
+
+// adoptListenerAddress makes sure SubscriptionURL can build a working
+// callback URL for a caller-supplied Config.EventsListener. If CallbackURL
+// is set, SubscriptionURL uses that directly and the listener's own address
+// is irrelevant. Otherwise the advertised ip/port are derived from the
+// listener's own TCP address so Marathon isn't registered against
+// "http://:0/...".
+func (r *marathonClient) adoptListenerAddress(listener net.Listener) error {
+	if r.config.CallbackURL != "" {
+		return nil
+	}
+
+	tcpAddr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		return fmt.Errorf("marathon: Config.EventsListener has a non-TCP address (%s); "+
+			"set Config.CallbackURL so a callback URL can be advertised", listener.Addr())
+	}
+
+	r.ipAddress = tcpAddr.IP.String()
+	r.config.EventsPort = tcpAddr.Port
+	return nil
+}
+
+// Close gracefully stops the event callback server, if one is running, and
+// removes its Marathon subscription; an SSE event stream is not closed. It
+// is equivalent to calling Shutdown with a 10 second timeout.
+func (r *marathonClient) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return r.Shutdown(ctx)
+}
+
+// Shutdown gracefully stops the event callback server, if one is running,
+// unregisters the callback subscription from Marathon, and honors ctx's
+// deadline/cancellation while waiting for in-flight callbacks to finish.
+func (r *marathonClient) Shutdown(ctx context.Context) error {
+	r.Lock()
+	server := r.eventsHTTP
+	r.eventsHTTP = nil
+	r.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	if r.config.EventsTransport == EventsTransportCallback {
+		if err := r.Unsubscribe(r.SubscriptionURL()); err != nil {
+			log.Printf("failed to unsubscribe the event callback, error: %s", err)
+		}
+	}
+
+	return server.Shutdown(ctx)
+}
+
+// SubscriptionState returns the current state of the SSE event stream subscription.
+func (r *marathonClient) SubscriptionState() SubscriptionState {
+	r.sseMu.RLock()
+	defer r.sseMu.RUnlock()
+	return r.sseState
+}
+
+// SSEErrors returns a channel on which SSE connection and reconnect errors
+// are published. It is optional to read from it: the reader goroutine never
+// blocks sending on it, so an error is dropped rather than delaying the
+// reconnect loop if no one is listening.
+func (r *marathonClient) SSEErrors() <-chan error {
+	r.sseMu.Lock()
+	defer r.sseMu.Unlock()
+	if r.sseErrors == nil {
+		r.sseErrors = make(chan error, 16)
+	}
+	return r.sseErrors
+}
+
+func (r *marathonClient) setSSEState(state SubscriptionState) {
+	r.sseMu.Lock()
+	r.sseState = state
+	r.sseMu.Unlock()
+}
+
+func (r *marathonClient) notifySSEError(err error) {
+	r.sseMu.RLock()
+	ch := r.sseErrors
+	r.sseMu.RUnlock()
+	if ch == nil {
+		return
+	}
+	select {
+	case ch <- err:
+	default:
+	}
+}
+
+// registerSSESubscription is reachable from AddEventsListenerCtx and the
+// typed Subscribe* methods while they still hold the client's main lock
+// (r.Lock), so everything it does from here down guards its own state with
+// the independent sseMu instead of r's lock, to avoid a self-deadlock on
+// that already-held lock.
 func (r *marathonClient) registerSSESubscription() error {
-	// Prevent multiple SSE subscriptions
+	r.sseMu.Lock()
 	if r.subscribedToSSE {
+		r.sseMu.Unlock()
 		return nil
 	}
+	// step: claim the subscription before connecting so two concurrent
+	// callers can't both start their own SSE stream
+	r.subscribedToSSE = true
+	r.sseMu.Unlock()
 
-	var stream *eventsource.Stream
+	stream, err := r.connectSSE()
+	if err != nil {
+		r.sseMu.Lock()
+		r.subscribedToSSE = false
+		r.sseMu.Unlock()
+		return err
+	}
+
+	go r.runSSE(stream)
+
+	return nil
+}
+
+// connectSSE tries, in turn, to establish an SSE stream against a member of
+// the cluster, marking members down as they fail, until a connection
+// succeeds or the cluster reports there is nothing left to try.
+func (r *marathonClient) connectSSE() (*eventsource.Stream, error) {
+	r.setSSEState(SubscriptionConnecting)
+
+	lastID, err := r.lastEventIDStore().Get()
+	if err != nil {
+		log.Printf("failed to read last SSE event ID, error: %s", err)
+	}
 
-	// Try to connect to Marathon until succeed or
-	// the whole custer is down
 	for {
 		// Get a member from the cluster
 		marathon, err := r.cluster.GetMember()
 		if err != nil {
-			return err
+			r.setSSEState(SubscriptionDisconnected)
+			return nil, err
 		}
 		url := fmt.Sprintf("%s/%s", marathon, marathonAPIEventStream)
 
-		// Try to connect to stream
-		stream, err = eventsource.Subscribe(url, "")
+		// Try to connect to stream, asking Marathon to replay anything
+		// since lastID if we have one
+		stream, err := eventsource.Subscribe(url, lastID)
 		if err == nil {
-			break
+			r.setSSEState(SubscriptionConnected)
+			return stream, nil
 		}
 
 		log.Printf("failed to connect to Marathon event stream, error: %s", err)
+		r.notifySSEError(err)
 		r.cluster.MarkDown()
 	}
+}
 
-	go func() {
-		for {
-			select {
-			case ev := <-stream.Events:
-				r.handleEvent(ev.Data())
-			case err := <-stream.Errors:
-				log.Printf("failed to receive event, error: %s", err)
+// runSSE pumps events off stream until it terminates, then reconnects
+// against another cluster member using exponential backoff with jitter.
+// It runs for the lifetime of the subscription and only returns once the
+// cluster has no members left to try.
+func (r *marathonClient) runSSE(stream *eventsource.Stream) {
+	backoff := r.sseReconnectBackoff()
+	maxBackoff := r.sseMaxBackoff()
+
+	for {
+		r.drainSSE(stream)
+
+		r.cluster.MarkDown()
+		r.setSSEState(SubscriptionReconnecting)
+		time.Sleep(withJitter(backoff))
+
+		newStream, err := r.connectSSE()
+		if err != nil {
+			r.notifySSEError(err)
+			// step: the cluster has nothing left to try; clear
+			// subscribedToSSE so a future AddEventsListener/Subscribe*
+			// call registers and retries instead of finding the
+			// subscription permanently "already on" and doing nothing
+			r.sseMu.Lock()
+			r.subscribedToSSE = false
+			r.sseMu.Unlock()
+			return
+		}
+		stream = newStream
+		backoff = nextBackoff(backoff, maxBackoff)
+	}
+}
+
+// drainSSE reads events from stream until it terminates, either via a fatal
+// error on stream.Errors, the underlying channels being closed, or the
+// heartbeat watchdog forcing a reconnect because nothing arrived within
+// Config.SSEHeartbeatTimeout.
+func (r *marathonClient) drainSSE(stream *eventsource.Stream) {
+	heartbeat := r.sseHeartbeatTimeout()
+
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	if heartbeat > 0 {
+		timer = time.NewTimer(heartbeat)
+		defer timer.Stop()
+		timerC = timer.C
+	}
+
+	for {
+		select {
+		case ev, ok := <-stream.Events:
+			if !ok {
+				return
+			}
+			r.recordLastEventID(ev)
+			r.handleEvent(ev.Data())
+			if timer != nil {
+				resetTimer(timer, heartbeat)
 			}
+		case err, ok := <-stream.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("failed to receive event, error: %s", err)
+			r.notifySSEError(err)
+			return
+		case <-timerC:
+			log.Printf("no SSE event received within %s, forcing reconnect", heartbeat)
+			r.notifySSEError(errSSEHeartbeatTimeout)
+			stream.Close()
+			return
 		}
-	}()
+	}
+}
 
-	r.subscribedToSSE = true
-	return nil
+// recordLastEventID persists ev's ID, if any, so a subsequent reconnect can
+// resume from it via Last-Event-ID.
+func (r *marathonClient) recordLastEventID(ev eventsource.Event) {
+	id := ev.Id()
+	if id == "" {
+		return
+	}
+	if err := r.lastEventIDStore().Set(id); err != nil {
+		log.Printf("failed to persist last SSE event ID, error: %s", err)
+	}
+}
+
+func (r *marathonClient) lastEventIDStore() SSELastEventIDStore {
+	r.sseMu.Lock()
+	defer r.sseMu.Unlock()
+	if r.config.SSELastEventIDStore == nil {
+		r.config.SSELastEventIDStore = new(memoryLastEventIDStore)
+	}
+	return r.config.SSELastEventIDStore
+}
+
+func (r *marathonClient) sseHeartbeatTimeout() time.Duration {
+	return r.config.SSEHeartbeatTimeout
+}
+
+// resetTimer drains timer if it already fired before re-arming it for d,
+// as required by the time.Timer.Reset contract.
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
+
+func (r *marathonClient) sseReconnectBackoff() time.Duration {
+	if r.config.SSEReconnectBackoff > 0 {
+		return r.config.SSEReconnectBackoff
+	}
+	return defaultSSEReconnectBackoff
+}
+
+func (r *marathonClient) sseMaxBackoff() time.Duration {
+	if r.config.SSEMaxBackoff > 0 {
+		return r.config.SSEMaxBackoff
+	}
+	return defaultSSEMaxBackoff
+}
+
+// nextBackoff doubles previous, capped at max.
+func nextBackoff(previous, max time.Duration) time.Duration {
+	next := previous * 2
+	if next > max {
+		return max
+	}
+	return next
+}
+
+// withJitter adds up to 20% random jitter on top of d, so that cluster
+// members reconnecting after a shared outage don't all retry in lockstep.
+func withJitter(d time.Duration) time.Duration {
+	jitter := time.Duration(rand.Int63n(int64(d)/5 + 1))
+	return d + jitter
 }
 
 // Unsubscribe removes ourselves from Marathon's callback facility
@@ -243,21 +697,92 @@ func (r *marathonClient) handleEvent(content string) {
 		return
 	}
 
+	// step: fan the decoded event out to any typed Feed subscribers; this
+	// runs regardless of whether anyone is on the legacy listeners map. The
+	// legacy listeners below are served by their own loop over r.listeners,
+	// not through a Feed.
+	r.feeds.send(event.Event)
+
 	r.RLock()
 	defer r.RUnlock()
 
 	// step: check if anyone is listen for this event
-	for channel, filter := range r.listeners {
+	for channel, reg := range r.listeners {
 		// step: check if this listener wants this event type
-		if event.ID&filter != 0 {
-			go func(ch EventsChannel, e *Event) {
-				ch <- e
-			}(channel, event)
+		if reg.wants(event, eventType.EventType) {
+			go r.deliver(channel, reg, event)
+		}
+	}
+}
+
+// deliver sends event to channel, applying reg.options.SendTimeout and
+// reg.options.SlowConsumerPolicy if the listener isn't keeping up, and
+// recording delivery metrics on reg. It also gives up, without ever sending,
+// once reg.ctx is done, so a canceled listener can't leak this goroutine
+// forever on a send that nobody will ever read.
+func (r *marathonClient) deliver(channel EventsChannel, reg *listenerRegistration, event *Event) {
+	if reg.options.SendTimeout <= 0 {
+		select {
+		case channel <- event:
+			atomic.AddUint64(&reg.metrics.Delivered, 1)
+		case <-reg.ctx.Done():
+			atomic.AddUint64(&reg.metrics.Dropped, 1)
+		}
+		return
+	}
+
+	start := time.Now()
+	timer := time.NewTimer(reg.options.SendTimeout)
+	defer timer.Stop()
+
+	select {
+	case channel <- event:
+		atomic.AddUint64(&reg.metrics.Delivered, 1)
+	case <-timer.C:
+		atomic.AddInt64(&reg.metrics.BlockedMS, time.Since(start).Milliseconds())
+		r.applySlowConsumerPolicy(channel, reg, event)
+	case <-reg.ctx.Done():
+		atomic.AddUint64(&reg.metrics.Dropped, 1)
+	}
+}
+
+// applySlowConsumerPolicy handles event once delivery has already blocked
+// past reg.options.SendTimeout.
+func (r *marathonClient) applySlowConsumerPolicy(channel EventsChannel, reg *listenerRegistration, event *Event) {
+	switch reg.options.SlowConsumerPolicy {
+	case DropNewest:
+		atomic.AddUint64(&reg.metrics.Dropped, 1)
+	case DropOldest:
+		select {
+		case <-channel:
+			atomic.AddUint64(&reg.metrics.Dropped, 1)
+		default:
+		}
+		select {
+		case channel <- event:
+			atomic.AddUint64(&reg.metrics.Delivered, 1)
+		default:
+			atomic.AddUint64(&reg.metrics.Dropped, 1)
+		}
+	default: // Block
+		select {
+		case channel <- event:
+			atomic.AddUint64(&reg.metrics.Delivered, 1)
+		case <-reg.ctx.Done():
+			atomic.AddUint64(&reg.metrics.Dropped, 1)
 		}
 	}
 }
 
 func (r *marathonClient) handleCallbackEvent(writer http.ResponseWriter, request *http.Request) {
+	if auth := r.config.CallbackAuthenticator; auth != nil {
+		if err := auth(request); err != nil {
+			log.Printf("rejected event callback, error: %s", err)
+			http.Error(writer, "forbidden", http.StatusForbidden)
+			return
+		}
+	}
+
 	body, err := ioutil.ReadAll(request.Body)
 	if err != nil {
 		log.Printf("failed to read request body, error: %s", err)
@@ -0,0 +1,236 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeCluster is a minimal stand-in for the real cluster implementation,
+// satisfying whatever interface r.cluster needs (GetMember/MarkDown) so SSE
+// registration can be driven against a local httptest server instead of a
+// live Marathon cluster.
+type fakeCluster struct {
+	addr string
+}
+
+func (f *fakeCluster) GetMember() (string, error) {
+	return f.addr, nil
+}
+
+func (f *fakeCluster) MarkDown() {}
+
+// newSSEServer starts an httptest server that looks enough like a Marathon
+// event stream for eventsource.Subscribe to connect successfully: it sends
+// a single event and then holds the connection open until the test closes
+// the server.
+func newSSEServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "data: {}\n\n")
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		<-r.Context().Done()
+	}))
+}
+
+func TestListenerRegistrationWantsEventTypesWithoutFilter(t *testing.T) {
+	reg := &listenerRegistration{options: ListenerOptions{
+		EventTypes: []string{"deployment_success"},
+	}}
+	event := &Event{ID: 0}
+
+	if !reg.wants(event, "deployment_success") {
+		t.Fatal("expected an EventTypes-only registration to match without a bitmask Filter")
+	}
+	if reg.wants(event, "status_update_event") {
+		t.Fatal("expected the EventTypes allowlist to still exclude other event types")
+	}
+}
+
+func TestListenerRegistrationWantsBitmaskFilter(t *testing.T) {
+	reg := &listenerRegistration{options: ListenerOptions{Filter: 2}}
+
+	if !reg.wants(&Event{ID: 2}, "anything") {
+		t.Fatal("expected a matching bitmask to be delivered")
+	}
+	if reg.wants(&Event{ID: 1}, "anything") {
+		t.Fatal("expected a non-matching bitmask to be filtered out")
+	}
+}
+
+func TestListenerRegistrationWantsFilterAndEventTypesCombine(t *testing.T) {
+	reg := &listenerRegistration{options: ListenerOptions{
+		Filter:     2,
+		EventTypes: []string{"deployment_success"},
+	}}
+
+	if reg.wants(&Event{ID: 2}, "status_update_event") {
+		t.Fatal("expected the EventTypes allowlist to still apply alongside a bitmask Filter")
+	}
+	if !reg.wants(&Event{ID: 2}, "deployment_success") {
+		t.Fatal("expected an event matching both Filter and EventTypes to be delivered")
+	}
+}
+
+func TestNextBackoffDoublesAndCaps(t *testing.T) {
+	if got := nextBackoff(time.Second, 10*time.Second); got != 2*time.Second {
+		t.Fatalf("expected backoff to double to 2s, got %s", got)
+	}
+	if got := nextBackoff(8*time.Second, 10*time.Second); got != 10*time.Second {
+		t.Fatalf("expected backoff to cap at 10s, got %s", got)
+	}
+}
+
+func TestWithJitterStaysWithinBounds(t *testing.T) {
+	d := 10 * time.Second
+	for i := 0; i < 100; i++ {
+		got := withJitter(d)
+		if got < d || got > d+d/5 {
+			t.Fatalf("jittered backoff %s outside expected bounds [%s, %s]", got, d, d+d/5)
+		}
+	}
+}
+
+func TestDeliverStopsOnContextCancellation(t *testing.T) {
+	client := &marathonClient{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	reg := &listenerRegistration{ctx: ctx, options: ListenerOptions{SlowConsumerPolicy: Block}}
+	channel := make(EventsChannel) // unbuffered and never read from
+
+	done := make(chan struct{})
+	go func() {
+		client.deliver(channel, reg, &Event{ID: 1})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected deliver to return once the listener context is canceled")
+	}
+
+	if got := reg.metrics.Dropped; got != 1 {
+		t.Fatalf("expected 1 dropped event, got %d", got)
+	}
+}
+
+func TestResetTimerRearmsAfterFiring(t *testing.T) {
+	timer := time.NewTimer(time.Millisecond)
+	<-timer.C // let it fire naturally before rearming
+
+	resetTimer(timer, 50*time.Millisecond)
+
+	select {
+	case <-timer.C:
+		t.Fatal("timer fired before its rearmed duration elapsed")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	<-timer.C
+}
+
+func TestResetTimerRearmsBeforeFiring(t *testing.T) {
+	timer := time.NewTimer(time.Hour) // won't fire on its own during the test
+
+	resetTimer(timer, 10*time.Millisecond)
+
+	select {
+	case <-timer.C:
+	case <-time.After(time.Second):
+		t.Fatal("expected resetTimer to rearm a not-yet-fired timer to the new duration")
+	}
+}
+
+// TestAddEventsListenerCtxRegistersSSEWithoutDeadlocking exercises
+// AddEventsListenerCtx end to end against a client configured for
+// EventsTransportSSE: AddEventsListenerCtx holds the client's main lock
+// across registerSubscription -> registerSSESubscription -> connectSSE, so
+// any SSE bookkeeping that tried to re-acquire that same lock would hang
+// here forever instead of returning.
+func TestAddEventsListenerCtxRegistersSSEWithoutDeadlocking(t *testing.T) {
+	server := newSSEServer(t)
+	defer server.Close()
+
+	client := &marathonClient{
+		config:    Config{EventsTransport: EventsTransportSSE},
+		cluster:   &fakeCluster{addr: server.URL},
+		listeners: make(map[EventsChannel]*listenerRegistration),
+	}
+
+	ch := make(EventsChannel, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.AddEventsListenerCtx(context.Background(), ch, ListenerOptions{
+			Filter:             -1,
+			SlowConsumerPolicy: Block,
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("AddEventsListenerCtx returned an error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("AddEventsListenerCtx deadlocked registering an SSE subscription")
+	}
+}
+
+// TestSubscribeDeploymentEventsRegistersSSEWithoutDeadlocking is the
+// Feed-based counterpart of the AddEventsListenerCtx test above: it holds
+// the same client lock across the same registerSubscription call chain.
+func TestSubscribeDeploymentEventsRegistersSSEWithoutDeadlocking(t *testing.T) {
+	server := newSSEServer(t)
+	defer server.Close()
+
+	client := &marathonClient{
+		config:    Config{EventsTransport: EventsTransportSSE},
+		cluster:   &fakeCluster{addr: server.URL},
+		listeners: make(map[EventsChannel]*listenerRegistration),
+	}
+
+	type result struct {
+		sub Subscription
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		sub, err := client.SubscribeDeploymentEvents(make(chan<- *EventDeploymentSuccess))
+		done <- result{sub, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			t.Fatalf("SubscribeDeploymentEvents returned an error: %v", r.err)
+		}
+		r.sub.Unsubscribe()
+	case <-time.After(2 * time.Second):
+		t.Fatal("SubscribeDeploymentEvents deadlocked registering an SSE subscription")
+	}
+}